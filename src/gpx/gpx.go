@@ -0,0 +1,170 @@
+// Package gpx reads and writes GPX tracks and correlates a timestamp
+// (typically a photo's capture time) against a track to georeference it.
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"point"
+)
+
+// GPSPoint is one point in a GPX track, linked to the next point recorded
+// in the same track.
+type GPSPoint struct {
+	Lat, Lon, Elev float64
+	Time           time.Time
+	Next           *GPSPoint
+}
+
+func (p *GPSPoint) GetLatitude() float64 {
+	return p.Lat
+}
+
+func (p *GPSPoint) GetLongitude() float64 {
+	return p.Lon
+}
+
+var _ point.LatLonPoint = (*GPSPoint)(nil)
+
+// Track is an ordered sequence of GPSPoints read from, or to be written
+// to, a single <trk> element.
+type Track struct {
+	Name  string
+	First *GPSPoint
+}
+
+// Points returns the track's points as a slice, in track order.
+func (t *Track) Points() []*GPSPoint {
+	var points []*GPSPoint
+	for p := t.First; p != nil; p = p.Next {
+		points = append(points, p)
+	}
+	return points
+}
+
+type gpxFile struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64   `xml:"lat,attr"`
+	Lon  float64   `xml:"lon,attr"`
+	Elev float64   `xml:"ele"`
+	Time time.Time `xml:"time"`
+}
+
+// Decode reads a GPX document and returns its tracks, preserving point
+// order, timestamps, and elevation. Multiple <trkseg> segments within a
+// track are flattened into a single linked list.
+func Decode(r io.Reader) ([]*Track, error) {
+	var file gpxFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("gpx: decode: %w", err)
+	}
+
+	tracks := make([]*Track, 0, len(file.Tracks))
+	for _, t := range file.Tracks {
+		track := &Track{Name: t.Name}
+		var tail *GPSPoint
+		for _, segment := range t.Segments {
+			for _, pt := range segment.Points {
+				p := &GPSPoint{Lat: pt.Lat, Lon: pt.Lon, Elev: pt.Elev, Time: pt.Time}
+				if tail == nil {
+					track.First = p
+				} else {
+					tail.Next = p
+				}
+				tail = p
+			}
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks, nil
+}
+
+// Encode writes tracks as a GPX document, one <trkseg> per track.
+func Encode(w io.Writer, tracks []*Track) error {
+	file := gpxFile{XMLName: xml.Name{Local: "gpx"}}
+	for _, t := range tracks {
+		segment := gpxSegment{}
+		for p := t.First; p != nil; p = p.Next {
+			segment.Points = append(segment.Points, gpxPoint{Lat: p.Lat, Lon: p.Lon, Elev: p.Elev, Time: p.Time})
+		}
+		file.Tracks = append(file.Tracks, gpxTrack{Name: t.Name, Segments: []gpxSegment{segment}})
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("gpx: encode: %w", err)
+	}
+	return nil
+}
+
+// DefaultCorrelateThreshold is the time window within which Correlate
+// snaps to an exact track point instead of interpolating.
+const DefaultCorrelateThreshold = 1 * time.Second
+
+// Correlate finds the point on track closest in time to photoTime,
+// interpolating between the two bracketing points (linearly, across
+// latitude, longitude, and elevation) unless one of them falls within
+// DefaultCorrelateThreshold, in which case that point is returned as-is.
+func Correlate(photoTime time.Time, track *Track) (point.LatLonPoint, error) {
+	return CorrelateWithThreshold(photoTime, track, DefaultCorrelateThreshold)
+}
+
+// CorrelateWithThreshold is Correlate with a caller-supplied snap
+// threshold.
+func CorrelateWithThreshold(photoTime time.Time, track *Track, threshold time.Duration) (point.LatLonPoint, error) {
+	if track == nil || track.First == nil {
+		return nil, errors.New("gpx: track has no points")
+	}
+
+	var before, after *GPSPoint
+	for p := track.First; p != nil; p = p.Next {
+		if !p.Time.After(photoTime) {
+			before = p
+			continue
+		}
+		after = p
+		break
+	}
+
+	switch {
+	case before != nil && absDuration(before.Time.Sub(photoTime)) <= threshold:
+		return point.KmlPoint{Latitude: before.Lat, Longitude: before.Lon, Altitude: before.Elev}, nil
+	case after != nil && absDuration(after.Time.Sub(photoTime)) <= threshold:
+		return point.KmlPoint{Latitude: after.Lat, Longitude: after.Lon, Altitude: after.Elev}, nil
+	case before == nil || after == nil:
+		return nil, fmt.Errorf("gpx: photo time %s is outside track range", photoTime)
+	}
+
+	span := after.Time.Sub(before.Time)
+	t := float64(photoTime.Sub(before.Time)) / float64(span)
+	return point.KmlPoint{
+		Latitude:  before.Lat + (after.Lat-before.Lat)*t,
+		Longitude: before.Lon + (after.Lon-before.Lon)*t,
+		Altitude:  before.Elev + (after.Elev-before.Elev)*t,
+	}, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,161 @@
+package gpx
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func chainTrack(points []*GPSPoint) *Track {
+	for i := 0; i+1 < len(points); i++ {
+		points[i].Next = points[i+1]
+	}
+	var first *GPSPoint
+	if len(points) > 0 {
+		first = points[0]
+	}
+	return &Track{First: first}
+}
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("parse time %q: %v", s, err)
+	}
+	return parsed
+}
+
+func TestCorrelateSnapsToBeforeWithinThreshold(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Elev: 100, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 30, Elev: 200, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	photoTime := before.Time.Add(500 * time.Millisecond)
+	got, err := CorrelateWithThreshold(photoTime, track, 1*time.Second)
+	if err != nil {
+		t.Fatalf("CorrelateWithThreshold returned error: %v", err)
+	}
+	if got.GetLatitude() != before.Lat || got.GetLongitude() != before.Lon {
+		t.Errorf("CorrelateWithThreshold snapped to %+v, want before point %+v", got, before)
+	}
+}
+
+func TestCorrelateSnapsToAfterWithinThreshold(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Elev: 100, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 30, Elev: 200, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	photoTime := after.Time.Add(-500 * time.Millisecond)
+	got, err := CorrelateWithThreshold(photoTime, track, 1*time.Second)
+	if err != nil {
+		t.Fatalf("CorrelateWithThreshold returned error: %v", err)
+	}
+	if got.GetLatitude() != after.Lat || got.GetLongitude() != after.Lon {
+		t.Errorf("CorrelateWithThreshold snapped to %+v, want after point %+v", got, after)
+	}
+}
+
+func TestCorrelateInterpolatesBetweenBracketingPoints(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Elev: 100, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 40, Elev: 200, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	photoTime := before.Time.Add(5 * time.Minute) // halfway, well outside the snap threshold
+	got, err := CorrelateWithThreshold(photoTime, track, 1*time.Second)
+	if err != nil {
+		t.Fatalf("CorrelateWithThreshold returned error: %v", err)
+	}
+	if got.GetLatitude() != 15 || got.GetLongitude() != 30 {
+		t.Errorf("CorrelateWithThreshold = %+v, want lat=15 lon=30 (midpoint)", got)
+	}
+}
+
+func TestCorrelateBeforeTrackStartReturnsError(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 40, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	_, err := CorrelateWithThreshold(before.Time.Add(-time.Hour), track, 1*time.Second)
+	if err == nil {
+		t.Fatalf("CorrelateWithThreshold(time before track start) returned nil error, want error")
+	}
+}
+
+func TestCorrelateAfterTrackEndReturnsError(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 40, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	_, err := CorrelateWithThreshold(after.Time.Add(time.Hour), track, 1*time.Second)
+	if err == nil {
+		t.Fatalf("CorrelateWithThreshold(time after track end) returned nil error, want error")
+	}
+}
+
+func TestCorrelateEmptyTrackReturnsError(t *testing.T) {
+	_, err := CorrelateWithThreshold(mustTime(t, "2026-01-01T00:00:00Z"), &Track{}, 1*time.Second)
+	if err == nil {
+		t.Fatalf("CorrelateWithThreshold(empty track) returned nil error, want error")
+	}
+}
+
+func TestCorrelateExactMatchSnaps(t *testing.T) {
+	before := &GPSPoint{Lat: 10, Lon: 20, Elev: 100, Time: mustTime(t, "2026-01-01T00:00:00Z")}
+	after := &GPSPoint{Lat: 20, Lon: 40, Elev: 200, Time: mustTime(t, "2026-01-01T00:10:00Z")}
+	track := chainTrack([]*GPSPoint{before, after})
+
+	got, err := Correlate(before.Time, track)
+	if err != nil {
+		t.Fatalf("Correlate returned error: %v", err)
+	}
+	if got.GetLatitude() != before.Lat || got.GetLongitude() != before.Lon {
+		t.Errorf("Correlate(exact match) = %+v, want before point %+v", got, before)
+	}
+}
+
+func TestDecodeEncodeRoundTrip(t *testing.T) {
+	track := chainTrack([]*GPSPoint{
+		{Lat: 37.7749, Lon: -122.4194, Elev: 16, Time: mustTime(t, "2026-01-01T00:00:00Z")},
+		{Lat: 37.8044, Lon: -122.2712, Elev: 42, Time: mustTime(t, "2026-01-01T00:10:00Z")},
+	})
+	track.Name = "test track"
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, []*Track{track}); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Decode returned %d tracks, want 1", len(got))
+	}
+	if got[0].Name != track.Name {
+		t.Errorf("Decode track name = %q, want %q", got[0].Name, track.Name)
+	}
+
+	want := track.Points()
+	points := got[0].Points()
+	if len(points) != len(want) {
+		t.Fatalf("Decode returned %d points, want %d", len(points), len(want))
+	}
+	for i, w := range want {
+		p := points[i]
+		if p.Lat != w.Lat || p.Lon != w.Lon || p.Elev != w.Elev || !p.Time.Equal(w.Time) {
+			t.Errorf("point %d = %+v, want %+v", i, p, w)
+		}
+	}
+}
+
+func TestDecodeEmptyDocumentReturnsNoTracks(t *testing.T) {
+	got, err := Decode(bytes.NewBufferString(`<gpx></gpx>`))
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Decode(empty document) returned %d tracks, want 0", len(got))
+	}
+}
@@ -0,0 +1,106 @@
+// Package kml reads and writes the subset of KML (Keyhole Markup
+// Language) needed to round-trip a flat list of point.KmlPoint values:
+// one <Placemark><Point> per point, with optional altitude.
+package kml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"point"
+)
+
+type kmlDocument struct {
+	XMLName  xml.Name  `xml:"kml"`
+	Document kmlFolder `xml:"Document"`
+}
+
+type kmlFolder struct {
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Point kmlPoint `xml:"Point"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// Decode reads a KML document and returns the points carried by its
+// Placemark/Point elements, in document order.
+func Decode(r io.Reader) ([]point.KmlPoint, error) {
+	var doc kmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("kml: decode: %w", err)
+	}
+
+	points := make([]point.KmlPoint, 0, len(doc.Document.Placemarks))
+	for _, placemark := range doc.Document.Placemarks {
+		p, err := parseCoordinates(placemark.Point.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// Encode writes points as a KML document with one Placemark/Point per
+// point.
+func Encode(w io.Writer, points []point.KmlPoint) error {
+	doc := kmlDocument{
+		XMLName:  xml.Name{Local: "kml"},
+		Document: kmlFolder{Placemarks: make([]kmlPlacemark, len(points))},
+	}
+	for i, p := range points {
+		doc.Document.Placemarks[i] = kmlPlacemark{
+			Point: kmlPoint{Coordinates: formatCoordinates(p)},
+		}
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("kml: encode: %w", err)
+	}
+	return nil
+}
+
+// parseCoordinates parses a KML "lon,lat[,alt]" coordinate tuple.
+func parseCoordinates(raw string) (point.KmlPoint, error) {
+	fields := strings.Split(strings.TrimSpace(raw), ",")
+	if len(fields) < 2 {
+		return point.KmlPoint{}, fmt.Errorf("kml: malformed coordinates %q", raw)
+	}
+
+	lon, err := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+	if err != nil {
+		return point.KmlPoint{}, fmt.Errorf("kml: longitude: %w", err)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+	if err != nil {
+		return point.KmlPoint{}, fmt.Errorf("kml: latitude: %w", err)
+	}
+
+	var alt float64
+	if len(fields) >= 3 && strings.TrimSpace(fields[2]) != "" {
+		alt, err = strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if err != nil {
+			return point.KmlPoint{}, fmt.Errorf("kml: altitude: %w", err)
+		}
+	}
+
+	return point.KmlPoint{Latitude: lat, Longitude: lon, Altitude: alt}, nil
+}
+
+func formatCoordinates(p point.KmlPoint) string {
+	return formatFloat(p.Longitude) + "," + formatFloat(p.Latitude) + "," + formatFloat(p.Altitude)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package kml
+
+import (
+	"bytes"
+	"testing"
+
+	"point"
+)
+
+func TestDecodeEncodeRoundTrip(t *testing.T) {
+	points := []point.KmlPoint{
+		{Latitude: 37.7749, Longitude: -122.4194, Altitude: 16},
+		{Latitude: 40.7128, Longitude: -74.0060},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, points); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(got) != len(points) {
+		t.Fatalf("Decode returned %d points, want %d", len(got), len(points))
+	}
+	for i, want := range points {
+		if got[i] != want {
+			t.Errorf("point %d = %+v, want %+v", i, got[i], want)
+		}
+	}
+}
+
+func TestDecodeMalformedCoordinatesReturnsError(t *testing.T) {
+	const doc = `<kml><Document><Placemark><Point><coordinates>not-a-number</coordinates></Point></Placemark></Document></kml>`
+
+	_, err := Decode(bytes.NewBufferString(doc))
+	if err == nil {
+		t.Fatalf("Decode(malformed coordinates) returned nil error, want error")
+	}
+}
+
+func TestParseCoordinatesTooFewFieldsReturnsError(t *testing.T) {
+	_, err := parseCoordinates("-122.4194")
+	if err == nil {
+		t.Fatalf("parseCoordinates(single field) returned nil error, want error")
+	}
+}
+
+func TestParseCoordinatesWithAltitude(t *testing.T) {
+	p, err := parseCoordinates("-122.4194,37.7749,16")
+	if err != nil {
+		t.Fatalf("parseCoordinates returned error: %v", err)
+	}
+	want := point.KmlPoint{Latitude: 37.7749, Longitude: -122.4194, Altitude: 16}
+	if p != want {
+		t.Errorf("parseCoordinates = %+v, want %+v", p, want)
+	}
+}
+
+func TestParseCoordinatesWithoutAltitude(t *testing.T) {
+	p, err := parseCoordinates("-122.4194,37.7749")
+	if err != nil {
+		t.Fatalf("parseCoordinates returned error: %v", err)
+	}
+	want := point.KmlPoint{Latitude: 37.7749, Longitude: -122.4194}
+	if p != want {
+		t.Errorf("parseCoordinates = %+v, want %+v", p, want)
+	}
+}
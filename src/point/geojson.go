@@ -0,0 +1,232 @@
+package point
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*
+	RFC 7946 GeoJSON support, built on top of LatLonPoint so that any
+	implementer (KmlPoint, GeoPoint, GeoJsonPoint, ...) can be lifted into a
+	GeoJSON Feature without the caller needing to know its concrete type.
+*/
+
+// GeometryType is the "type" discriminator on a GeoJSON geometry object.
+type GeometryType string
+
+const (
+	GeometryTypePoint              GeometryType = "Point"
+	GeometryTypeLineString         GeometryType = "LineString"
+	GeometryTypePolygon            GeometryType = "Polygon"
+	GeometryTypeMultiPoint         GeometryType = "MultiPoint"
+	GeometryTypeMultiPolygon       GeometryType = "MultiPolygon"
+	GeometryTypeGeometryCollection GeometryType = "GeometryCollection"
+)
+
+// Geometry is a tagged union over the RFC 7946 geometry variants. Only the
+// field matching Type is populated; this mirrors the variant-handling
+// pattern used by the Google Maps GeoJsonGeometry type, where a single
+// struct carries one optional field per possible shape instead of an
+// interface{} per coordinate level.
+type Geometry struct {
+	Type GeometryType
+
+	Point        []float64     // Point
+	LineString   [][]float64   // LineString, MultiPoint
+	Polygon      [][][]float64 // Polygon
+	MultiPolygon [][][][]float64
+	Geometries   []Geometry // GeometryCollection
+}
+
+// MarshalJSON emits the coordinates (or nested geometries) under the key
+// required by the geometry's Type discriminator.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	switch g.Type {
+	case GeometryTypePoint:
+		return json.Marshal(struct {
+			Type        GeometryType `json:"type"`
+			Coordinates []float64    `json:"coordinates"`
+		}{g.Type, g.Point})
+	case GeometryTypeLineString, GeometryTypeMultiPoint:
+		return json.Marshal(struct {
+			Type        GeometryType `json:"type"`
+			Coordinates [][]float64  `json:"coordinates"`
+		}{g.Type, g.LineString})
+	case GeometryTypePolygon:
+		return json.Marshal(struct {
+			Type        GeometryType  `json:"type"`
+			Coordinates [][][]float64 `json:"coordinates"`
+		}{g.Type, g.Polygon})
+	case GeometryTypeMultiPolygon:
+		return json.Marshal(struct {
+			Type        GeometryType    `json:"type"`
+			Coordinates [][][][]float64 `json:"coordinates"`
+		}{g.Type, g.MultiPolygon})
+	case GeometryTypeGeometryCollection:
+		return json.Marshal(struct {
+			Type       GeometryType `json:"type"`
+			Geometries []Geometry   `json:"geometries"`
+		}{g.Type, g.Geometries})
+	default:
+		return nil, fmt.Errorf("point: unknown geometry type %q", g.Type)
+	}
+}
+
+// UnmarshalJSON reads the "type" discriminator first, then decodes the
+// matching coordinates/geometries field.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var head struct {
+		Type GeometryType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	g.Type = head.Type
+
+	switch head.Type {
+	case GeometryTypePoint:
+		var v struct {
+			Coordinates []float64 `json:"coordinates"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		g.Point = v.Coordinates
+	case GeometryTypeLineString, GeometryTypeMultiPoint:
+		var v struct {
+			Coordinates [][]float64 `json:"coordinates"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		g.LineString = v.Coordinates
+	case GeometryTypePolygon:
+		var v struct {
+			Coordinates [][][]float64 `json:"coordinates"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		g.Polygon = v.Coordinates
+	case GeometryTypeMultiPolygon:
+		var v struct {
+			Coordinates [][][][]float64 `json:"coordinates"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		g.MultiPolygon = v.Coordinates
+	case GeometryTypeGeometryCollection:
+		var v struct {
+			Geometries []Geometry `json:"geometries"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		g.Geometries = v.Geometries
+	default:
+		return fmt.Errorf("point: unknown geometry type %q", head.Type)
+	}
+	return nil
+}
+
+// GeoJsonFeature is a GeoJSON Feature: a geometry plus a free-form
+// properties bag and an optional id.
+type GeoJsonFeature struct {
+	ID         interface{}
+	Geometry   Geometry
+	Properties map[string]interface{}
+}
+
+func (f GeoJsonFeature) MarshalJSON() ([]byte, error) {
+	properties := f.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		ID         interface{}            `json:"id,omitempty"`
+		Geometry   Geometry               `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}{"Feature", f.ID, f.Geometry, properties})
+}
+
+func (f *GeoJsonFeature) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type       string                 `json:"type"`
+		ID         interface{}            `json:"id"`
+		Geometry   Geometry               `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Type != "Feature" {
+		return fmt.Errorf("point: expected Feature, got %q", aux.Type)
+	}
+	f.ID = aux.ID
+	f.Geometry = aux.Geometry
+	f.Properties = aux.Properties
+	return nil
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection: an ordered list of
+// Features.
+type FeatureCollection struct {
+	Features []GeoJsonFeature
+}
+
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := fc.Features
+	if features == nil {
+		features = []GeoJsonFeature{}
+	}
+	return json.Marshal(struct {
+		Type     string           `json:"type"`
+		Features []GeoJsonFeature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Type     string           `json:"type"`
+		Features []GeoJsonFeature `json:"features"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Type != "FeatureCollection" {
+		return fmt.Errorf("point: expected FeatureCollection, got %q", aux.Type)
+	}
+	fc.Features = aux.Features
+	return nil
+}
+
+// NewPointFeature lifts any LatLonPoint implementation (KmlPoint, GeoPoint,
+// GeoJsonPoint, or a caller's own type) into a GeoJSON Point Feature,
+// carrying along the supplied properties.
+func NewPointFeature(p LatLonPoint, properties map[string]interface{}) GeoJsonFeature {
+	return GeoJsonFeature{
+		Geometry: Geometry{
+			Type:  GeometryTypePoint,
+			Point: []float64{p.GetLongitude(), p.GetLatitude()},
+		},
+		Properties: properties,
+	}
+}
+
+// NewLineStringFeature lifts an ordered slice of LatLonPoints into a
+// GeoJSON LineString Feature.
+func NewLineStringFeature(points []LatLonPoint, properties map[string]interface{}) GeoJsonFeature {
+	coordinates := make([][]float64, len(points))
+	for i, p := range points {
+		coordinates[i] = []float64{p.GetLongitude(), p.GetLatitude()}
+	}
+	return GeoJsonFeature{
+		Geometry: Geometry{
+			Type:       GeometryTypeLineString,
+			LineString: coordinates,
+		},
+		Properties: properties,
+	}
+}
@@ -0,0 +1,177 @@
+package point
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeometryRoundTripAllVariants(t *testing.T) {
+	cases := []struct {
+		name string
+		geom Geometry
+	}{
+		{"Point", Geometry{Type: GeometryTypePoint, Point: []float64{-122.4194, 37.7749}}},
+		{"LineString", Geometry{Type: GeometryTypeLineString, LineString: [][]float64{{-122.4194, 37.7749}, {-74.0060, 40.7128}}}},
+		{"Polygon", Geometry{Type: GeometryTypePolygon, Polygon: [][][]float64{{{0, 0}, {0, 1}, {1, 1}, {0, 0}}}}},
+		{"MultiPoint", Geometry{Type: GeometryTypeMultiPoint, LineString: [][]float64{{0, 0}, {1, 1}}}},
+		{"MultiPolygon", Geometry{Type: GeometryTypeMultiPolygon, MultiPolygon: [][][][]float64{{{{0, 0}, {0, 1}, {1, 1}, {0, 0}}}}}},
+		{
+			"GeometryCollection",
+			Geometry{
+				Type: GeometryTypeGeometryCollection,
+				Geometries: []Geometry{
+					{Type: GeometryTypePoint, Point: []float64{-122.4194, 37.7749}},
+					{Type: GeometryTypeLineString, LineString: [][]float64{{0, 0}, {1, 1}}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := json.Marshal(c.geom)
+			if err != nil {
+				t.Fatalf("Marshal(%s) returned error: %v", c.name, err)
+			}
+
+			var got Geometry
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) returned error: %v", c.name, err)
+			}
+			if !geometryEqual(got, c.geom) {
+				t.Errorf("round-trip %s = %+v, want %+v", c.name, got, c.geom)
+			}
+		})
+	}
+}
+
+func geometryEqual(a, b Geometry) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ja) == string(jb)
+}
+
+func TestGeometryMarshalUnknownTypeReturnsError(t *testing.T) {
+	g := Geometry{Type: "Circle"}
+	if _, err := json.Marshal(g); err == nil {
+		t.Fatalf("Marshal(unknown geometry type) returned nil error, want error")
+	}
+}
+
+func TestGeometryUnmarshalUnknownTypeReturnsError(t *testing.T) {
+	var g Geometry
+	err := json.Unmarshal([]byte(`{"type":"Circle","coordinates":[0,0]}`), &g)
+	if err == nil {
+		t.Fatalf("Unmarshal(unknown geometry type) returned nil error, want error")
+	}
+}
+
+func TestGeoJsonFeatureRoundTrip(t *testing.T) {
+	f := GeoJsonFeature{
+		ID:       "feature-1",
+		Geometry: Geometry{Type: GeometryTypePoint, Point: []float64{-122.4194, 37.7749}},
+		Properties: map[string]interface{}{
+			"name": "sf",
+		},
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("Marshal(feature) returned error: %v", err)
+	}
+
+	var got GeoJsonFeature
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(feature) returned error: %v", err)
+	}
+	if got.ID != f.ID {
+		t.Errorf("round-trip feature ID = %v, want %v", got.ID, f.ID)
+	}
+	if got.Properties["name"] != "sf" {
+		t.Errorf("round-trip feature properties = %v, want name=sf", got.Properties)
+	}
+	if !geometryEqual(got.Geometry, f.Geometry) {
+		t.Errorf("round-trip feature geometry = %+v, want %+v", got.Geometry, f.Geometry)
+	}
+}
+
+func TestGeoJsonFeatureUnmarshalWrongTypeReturnsError(t *testing.T) {
+	var f GeoJsonFeature
+	err := json.Unmarshal([]byte(`{"type":"Point"}`), &f)
+	if err == nil {
+		t.Fatalf("Unmarshal(feature with non-Feature type) returned nil error, want error")
+	}
+}
+
+func TestFeatureCollectionRoundTrip(t *testing.T) {
+	fc := FeatureCollection{
+		Features: []GeoJsonFeature{
+			NewPointFeature(GeoPoint{Latitude: 37.7749, Longitude: -122.4194}, map[string]interface{}{"name": "sf"}),
+			NewPointFeature(GeoPoint{Latitude: 40.7128, Longitude: -74.0060}, map[string]interface{}{"name": "nyc"}),
+		},
+	}
+
+	data, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatalf("Marshal(collection) returned error: %v", err)
+	}
+
+	var got FeatureCollection
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(collection) returned error: %v", err)
+	}
+	if len(got.Features) != len(fc.Features) {
+		t.Fatalf("round-trip collection has %d features, want %d", len(got.Features), len(fc.Features))
+	}
+}
+
+func TestFeatureCollectionUnmarshalWrongTypeReturnsError(t *testing.T) {
+	var fc FeatureCollection
+	err := json.Unmarshal([]byte(`{"type":"Feature"}`), &fc)
+	if err == nil {
+		t.Fatalf("Unmarshal(collection with non-FeatureCollection type) returned nil error, want error")
+	}
+}
+
+func TestNewPointFeature(t *testing.T) {
+	p := GeoPoint{Latitude: 37.7749, Longitude: -122.4194}
+	f := NewPointFeature(p, map[string]interface{}{"name": "sf"})
+
+	if f.Geometry.Type != GeometryTypePoint {
+		t.Fatalf("NewPointFeature geometry type = %v, want Point", f.Geometry.Type)
+	}
+	want := []float64{p.Longitude, p.Latitude}
+	if f.Geometry.Point[0] != want[0] || f.Geometry.Point[1] != want[1] {
+		t.Errorf("NewPointFeature coordinates = %v, want [lon, lat] = %v", f.Geometry.Point, want)
+	}
+	if f.Properties["name"] != "sf" {
+		t.Errorf("NewPointFeature properties = %v, want name=sf", f.Properties)
+	}
+}
+
+func TestNewLineStringFeature(t *testing.T) {
+	points := []LatLonPoint{
+		GeoPoint{Latitude: 37.7749, Longitude: -122.4194},
+		GeoPoint{Latitude: 40.7128, Longitude: -74.0060},
+	}
+	f := NewLineStringFeature(points, nil)
+
+	if f.Geometry.Type != GeometryTypeLineString {
+		t.Fatalf("NewLineStringFeature geometry type = %v, want LineString", f.Geometry.Type)
+	}
+	if len(f.Geometry.LineString) != len(points) {
+		t.Fatalf("NewLineStringFeature coordinates has %d points, want %d", len(f.Geometry.LineString), len(points))
+	}
+	for i, p := range points {
+		got := f.Geometry.LineString[i]
+		if got[0] != p.GetLongitude() || got[1] != p.GetLatitude() {
+			t.Errorf("NewLineStringFeature coordinate %d = %v, want [lon, lat] = [%v, %v]", i, got, p.GetLongitude(), p.GetLatitude())
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package point
+
+import (
+	"errors"
+	"math"
+)
+
+// meanEarthRadiusMeters is the WGS84 mean radius, used as the sphere
+// radius for the haversine/great-circle calculations below.
+const meanEarthRadiusMeters = 6371008.8
+
+// antipodalTolerance bounds how close to exactly antipodal two points can
+// be before Interpolate refuses to guess a great-circle path between
+// them.
+const antipodalTolerance = 1e-9
+
+// ErrAntipodal is returned by Interpolate when a and b are (to within
+// antipodalTolerance) antipodal, so the great-circle between them is not
+// unique.
+var ErrAntipodal = errors.New("point: a and b are antipodal; great-circle interpolation is undefined")
+
+/*
+	Geometry operations in terms of the LatLonPoint interface
+*/
+
+// Distance returns the great-circle distance between a and b, in meters,
+// using the haversine formula on the WGS84 mean radius.
+func Distance(a, b LatLonPoint) float64 {
+	lat1, lon1 := toRadians(a.GetLatitude()), toRadians(a.GetLongitude())
+	lat2, lon2 := toRadians(b.GetLatitude()), toRadians(b.GetLongitude())
+
+	dLat := lat2 - lat1
+	dLon := lon2 - lon1
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * meanEarthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// Bearing returns the initial compass bearing from a to b, in degrees
+// clockwise from true north, in [0, 360).
+func Bearing(a, b LatLonPoint) float64 {
+	lat1, lon1 := toRadians(a.GetLatitude()), toRadians(a.GetLongitude())
+	lat2, lon2 := toRadians(b.GetLatitude()), toRadians(b.GetLongitude())
+	dLon := lon2 - lon1
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+
+	bearing := toDegrees(math.Atan2(y, x))
+	return math.Mod(bearing+360, 360)
+}
+
+// Interpolate returns the point a fraction t of the way from a to b
+// along their great-circle, via spherical linear interpolation (slerp)
+// between their unit vectors. t=0 returns a, t=1 returns b. It returns
+// ErrAntipodal if a and b are (near) antipodal, since in that case
+// infinitely many great-circles connect them and there is no single
+// correct path to interpolate along.
+func Interpolate(a, b LatLonPoint, t float64) (LatLonPoint, error) {
+	ax, ay, az := toUnitVector(a)
+	bx, by, bz := toUnitVector(b)
+
+	angularDistance := math.Acos(clamp(ax*bx+ay*by+az*bz, -1, 1))
+	if angularDistance == 0 {
+		return GeoPoint{Latitude: a.GetLatitude(), Longitude: a.GetLongitude()}, nil
+	}
+
+	sinD := math.Sin(angularDistance)
+	if math.Abs(sinD) < antipodalTolerance {
+		return nil, ErrAntipodal
+	}
+	scaleA := math.Sin((1-t)*angularDistance) / sinD
+	scaleB := math.Sin(t*angularDistance) / sinD
+
+	x := scaleA*ax + scaleB*bx
+	y := scaleA*ay + scaleB*by
+	z := scaleA*az + scaleB*bz
+
+	return fromUnitVector(x, y, z), nil
+}
+
+// Centroid returns the geometric mean of points, computed by normalizing
+// the sum of their 3D unit vectors rather than naively averaging
+// latitude/longitude (which is wrong near the poles and across the
+// antimeridian).
+func Centroid(points []LatLonPoint) LatLonPoint {
+	if len(points) == 0 {
+		return GeoPoint{}
+	}
+
+	var sx, sy, sz float64
+	for _, p := range points {
+		x, y, z := toUnitVector(p)
+		sx += x
+		sy += y
+		sz += z
+	}
+	return fromUnitVector(sx, sy, sz)
+}
+
+func toUnitVector(p LatLonPoint) (x, y, z float64) {
+	lat, lon := toRadians(p.GetLatitude()), toRadians(p.GetLongitude())
+	x = math.Cos(lat) * math.Cos(lon)
+	y = math.Cos(lat) * math.Sin(lon)
+	z = math.Sin(lat)
+	return x, y, z
+}
+
+func fromUnitVector(x, y, z float64) GeoPoint {
+	return GeoPoint{
+		Latitude:  toDegrees(math.Atan2(z, math.Sqrt(x*x+y*y))),
+		Longitude: toDegrees(math.Atan2(y, x)),
+	}
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,93 @@
+package point
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestDistance(t *testing.T) {
+	sf := GeoPoint{Latitude: 37.7749, Longitude: -122.4194}
+	nyc := GeoPoint{Latitude: 40.7128, Longitude: -74.0060}
+
+	got := Distance(sf, nyc)
+	const wantMeters = 4129000 // approximate SF-NYC great-circle distance
+	const toleranceMeters = 20000
+	if !almostEqual(got, wantMeters, toleranceMeters) {
+		t.Errorf("Distance(SF, NYC) = %v, want ~%v (+/- %v)", got, wantMeters, toleranceMeters)
+	}
+
+	if d := Distance(sf, sf); d != 0 {
+		t.Errorf("Distance(p, p) = %v, want 0", d)
+	}
+}
+
+func TestBearingDueNorthAndEast(t *testing.T) {
+	origin := GeoPoint{Latitude: 0, Longitude: 0}
+	north := GeoPoint{Latitude: 1, Longitude: 0}
+	east := GeoPoint{Latitude: 0, Longitude: 1}
+
+	if b := Bearing(origin, north); !almostEqual(b, 0, 1e-6) {
+		t.Errorf("Bearing(origin, north) = %v, want 0", b)
+	}
+	if b := Bearing(origin, east); !almostEqual(b, 90, 1e-6) {
+		t.Errorf("Bearing(origin, east) = %v, want 90", b)
+	}
+}
+
+func TestInterpolateEndpoints(t *testing.T) {
+	a := GeoPoint{Latitude: 10, Longitude: 20}
+	b := GeoPoint{Latitude: -30, Longitude: 100}
+
+	start, err := Interpolate(a, b, 0)
+	if err != nil {
+		t.Fatalf("Interpolate(a, b, 0) returned error: %v", err)
+	}
+	if !almostEqual(start.GetLatitude(), a.Latitude, 1e-6) || !almostEqual(start.GetLongitude(), a.Longitude, 1e-6) {
+		t.Errorf("Interpolate(a, b, 0) = %+v, want a = %+v", start, a)
+	}
+
+	end, err := Interpolate(a, b, 1)
+	if err != nil {
+		t.Fatalf("Interpolate(a, b, 1) returned error: %v", err)
+	}
+	if !almostEqual(end.GetLatitude(), b.Latitude, 1e-6) || !almostEqual(end.GetLongitude(), b.Longitude, 1e-6) {
+		t.Errorf("Interpolate(a, b, 1) = %+v, want b = %+v", end, b)
+	}
+}
+
+func TestInterpolateAntipodalReturnsError(t *testing.T) {
+	a := GeoPoint{Latitude: 10, Longitude: 20}
+	b := GeoPoint{Latitude: -10, Longitude: -160}
+
+	_, err := Interpolate(a, b, 0.5)
+	if !errors.Is(err, ErrAntipodal) {
+		t.Fatalf("Interpolate(antipodal points, 0.5) error = %v, want ErrAntipodal", err)
+	}
+}
+
+func TestCentroidOfAntimeridianPoints(t *testing.T) {
+	points := []LatLonPoint{
+		GeoPoint{Latitude: 0, Longitude: 179},
+		GeoPoint{Latitude: 0, Longitude: -179},
+	}
+
+	c := Centroid(points)
+	if !almostEqual(c.GetLatitude(), 0, 1e-6) {
+		t.Errorf("Centroid latitude = %v, want 0", c.GetLatitude())
+	}
+	if !almostEqual(math.Abs(c.GetLongitude()), 180, 1e-6) {
+		t.Errorf("Centroid longitude = %v, want +/-180 (not the naive mean of 0)", c.GetLongitude())
+	}
+}
+
+func TestCentroidEmpty(t *testing.T) {
+	c := Centroid(nil)
+	if c.GetLatitude() != 0 || c.GetLongitude() != 0 {
+		t.Errorf("Centroid(nil) = %+v, want zero value", c)
+	}
+}
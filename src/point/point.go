@@ -0,0 +1,69 @@
+// Package point defines the LatLonPoint abstraction shared by the various
+// geo file formats (KML, GPX, GeoJSON) handled by this module, plus the
+// concrete point types that implement it.
+package point
+
+/*
+	Our three unmodifiable data types
+*/
+type KmlPoint struct {
+	Latitude, Longitude float64
+
+	// Optional
+	Altitude float64
+}
+
+type GeoPoint struct {
+	Latitude, Longitude float64
+}
+
+type GeoJsonPoint struct {
+	LatLon     [2]float64
+	Properties map[string]string
+}
+
+/*
+	Extract common interface
+*/
+type LatLonPoint interface {
+	GetLatitude() float64
+	GetLongitude() float64
+}
+
+/*
+	Adapt structures by adding methods; Interfaces only define methods, so properties must be wrapped
+*/
+func (p KmlPoint) GetLatitude() float64 {
+	return p.Latitude
+}
+
+func (p KmlPoint) GetLongitude() float64 {
+	return p.Longitude
+}
+
+func (p GeoPoint) GetLatitude() float64 {
+	return p.Latitude
+}
+
+func (p GeoPoint) GetLongitude() float64 {
+	return p.Longitude
+}
+
+func (p GeoJsonPoint) GetLatitude() float64 {
+	return p.LatLon[0]
+}
+
+func (p GeoJsonPoint) GetLongitude() float64 {
+	return p.LatLon[1]
+}
+
+/*
+	Define generic function in terms of interface
+*/
+func averagePoint(points []LatLonPoint) (float64, float64) {
+	if len(points) == 0 {
+		return 0, 0
+	}
+	centroid := Centroid(points)
+	return centroid.GetLatitude(), centroid.GetLongitude()
+}
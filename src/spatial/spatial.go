@@ -0,0 +1,242 @@
+// Package spatial indexes point.LatLonPoint values for range and
+// nearest-neighbor queries at a scale the naive point.Distance-over-every-
+// point approach doesn't reach.
+//
+// Points are keyed by a geohash-style Morton code: the quantized latitude
+// and longitude are interleaved bit-by-bit into a single uint64, so that
+// points near each other in space sort near each other in the key space.
+// Entries are kept in a slice sorted by that code. Range queries walk the
+// query bounding box as a quadtree, decomposing it into the contiguous
+// Morton-code ranges that cover it, binary-searching each range into the
+// sorted entries, and filtering candidates with a true haversine check.
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"point"
+)
+
+const (
+	earthRadiusMeters = 6371008.8
+	coordBits         = 32
+	maxQuadDepth      = 24
+)
+
+// Index is a Morton-code (Z-order) spatial index over point.LatLonPoint
+// values.
+type Index struct {
+	entries []indexEntry
+}
+
+type indexEntry struct {
+	code  uint64
+	point point.LatLonPoint
+}
+
+// NewIndex returns an empty Index.
+func NewIndex() *Index {
+	return &Index{}
+}
+
+// Insert adds p to the index.
+func (idx *Index) Insert(p point.LatLonPoint) {
+	code := mortonCode(p.GetLatitude(), p.GetLongitude())
+	i := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].code >= code })
+	idx.entries = append(idx.entries, indexEntry{})
+	copy(idx.entries[i+1:], idx.entries[i:])
+	idx.entries[i] = indexEntry{code: code, point: p}
+}
+
+// WithinRadius returns every indexed point within meters of q.
+func (idx *Index) WithinRadius(q point.LatLonPoint, meters float64) []point.LatLonPoint {
+	latMin, latMax, lonMin, lonMax := boundingBox(q, meters)
+	qLatMin, qLatMax := quantizeRange(latMin, latMax, -90, 90)
+
+	var results []point.LatLonPoint
+	for _, lonRange := range wrapLonRange(lonMin, lonMax) {
+		qLonMin, qLonMax := quantizeRange(lonRange[0], lonRange[1], -180, 180)
+		for _, r := range idx.coveringRanges(qLatMin, qLatMax, qLonMin, qLonMax) {
+			lo := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].code >= r.low })
+			hi := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].code > r.high })
+			for _, e := range idx.entries[lo:hi] {
+				if point.Distance(q, e.point) <= meters {
+					results = append(results, e.point)
+				}
+			}
+		}
+	}
+	return results
+}
+
+// wrapLonRange splits a [lonMin, lonMax] span that may extend past the
+// antimeridian into one or two sub-ranges that each fit within
+// [-180, 180], so a query circle near +/-180 degrees longitude doesn't
+// get silently clipped instead of wrapped.
+func wrapLonRange(lonMin, lonMax float64) [][2]float64 {
+	if lonMax-lonMin >= 360 {
+		return [][2]float64{{-180, 180}}
+	}
+	switch {
+	case lonMin < -180:
+		return [][2]float64{{lonMin + 360, 180}, {-180, lonMax}}
+	case lonMax > 180:
+		return [][2]float64{{lonMin, 180}, {-180, lonMax - 360}}
+	default:
+		return [][2]float64{{lonMin, lonMax}}
+	}
+}
+
+// NearestK returns the k indexed points closest to q, nearest first. It
+// searches an expanding radius around q until at least k candidates are
+// found (or the whole index has been covered), then ranks candidates by
+// true distance.
+func (idx *Index) NearestK(q point.LatLonPoint, k int) []point.LatLonPoint {
+	if k <= 0 || len(idx.entries) == 0 {
+		return nil
+	}
+
+	maxRadius := math.Pi * earthRadiusMeters
+	var candidates []point.LatLonPoint
+	for radius := 1000.0; ; radius *= 2 {
+		candidates = idx.WithinRadius(q, radius)
+		if len(candidates) >= k || radius >= maxRadius {
+			break
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return point.Distance(q, candidates[i]) < point.Distance(q, candidates[j])
+	})
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates
+}
+
+// codeRange is a contiguous [low, high] span of Morton codes.
+type codeRange struct {
+	low, high uint64
+}
+
+// coveringRanges decomposes the query bounding box (in quantized
+// lat/lon units) into the Morton-code ranges of the quadtree cells that
+// cover it.
+func (idx *Index) coveringRanges(qLatMin, qLatMax, qLonMin, qLonMax uint32) []codeRange {
+	var ranges []codeRange
+	collectRanges(0, math.MaxUint32, 0, math.MaxUint32, qLatMin, qLatMax, qLonMin, qLonMax, 0, &ranges)
+	return ranges
+}
+
+func collectRanges(cellLatMin, cellLatMax, cellLonMin, cellLonMax, targetLatMin, targetLatMax, targetLonMin, targetLonMax uint32, depth int, out *[]codeRange) {
+	if cellLatMax < targetLatMin || cellLatMin > targetLatMax || cellLonMax < targetLonMin || cellLonMin > targetLonMax {
+		return
+	}
+
+	fullyContained := cellLatMin >= targetLatMin && cellLatMax <= targetLatMax &&
+		cellLonMin >= targetLonMin && cellLonMax <= targetLonMax
+
+	// Once a cell has shrunk to the target box's own scale, stop
+	// subdividing even if the cell only partially overlaps: going finer
+	// would no longer meaningfully shrink the candidate set, just
+	// multiply the number of emitted ranges along the box's perimeter
+	// (quadratically in the worst case, since lat and lon boundaries
+	// both split at every level). Overlapping-but-not-contained cells
+	// emitted here are still correct because WithinRadius re-checks
+	// true distance on every candidate entry.
+	atTargetScale := cellLatMax-cellLatMin <= targetLatMax-targetLatMin &&
+		cellLonMax-cellLonMin <= targetLonMax-targetLonMin
+	if fullyContained || atTargetScale || depth >= maxQuadDepth || cellLatMax == cellLatMin {
+		*out = append(*out, codeRange{
+			low:  interleave(cellLatMin, cellLonMin),
+			high: interleave(cellLatMax, cellLonMax),
+		})
+		return
+	}
+
+	latMid := cellLatMin + (cellLatMax-cellLatMin)/2
+	lonMid := cellLonMin + (cellLonMax-cellLonMin)/2
+	collectRanges(cellLatMin, latMid, cellLonMin, lonMid, targetLatMin, targetLatMax, targetLonMin, targetLonMax, depth+1, out)
+	collectRanges(cellLatMin, latMid, lonMid+1, cellLonMax, targetLatMin, targetLatMax, targetLonMin, targetLonMax, depth+1, out)
+	collectRanges(latMid+1, cellLatMax, cellLonMin, lonMid, targetLatMin, targetLatMax, targetLonMin, targetLonMax, depth+1, out)
+	collectRanges(latMid+1, cellLatMax, lonMid+1, cellLonMax, targetLatMin, targetLatMax, targetLonMin, targetLonMax, depth+1, out)
+}
+
+// boundingBox returns the lat/lon box covering a circle of the given
+// radius around q, clamped to valid latitude range.
+func boundingBox(q point.LatLonPoint, meters float64) (latMin, latMax, lonMin, lonMax float64) {
+	lat := q.GetLatitude()
+	lon := q.GetLongitude()
+
+	latDelta := toDegrees(meters / earthRadiusMeters)
+	cosLat := math.Cos(toRadians(lat))
+
+	var lonDelta float64
+	if cosLat > 1e-9 {
+		lonDelta = toDegrees(meters / (earthRadiusMeters * cosLat))
+	} else {
+		lonDelta = 180
+	}
+	if lonDelta > 180 {
+		lonDelta = 180
+	}
+
+	return clampLat(lat - latDelta), clampLat(lat + latDelta), lon - lonDelta, lon + lonDelta
+}
+
+func clampLat(lat float64) float64 {
+	if lat < -90 {
+		return -90
+	}
+	if lat > 90 {
+		return 90
+	}
+	return lat
+}
+
+func mortonCode(lat, lon float64) uint64 {
+	return interleave(quantize(lat, -90, 90), quantize(lon, -180, 180))
+}
+
+// quantize maps value in [lo, hi] onto [0, 2^coordBits).
+func quantize(value, lo, hi float64) uint32 {
+	normalized := (value - lo) / (hi - lo)
+	if normalized < 0 {
+		normalized = 0
+	}
+	if normalized > 1 {
+		normalized = 1
+	}
+	return uint32(normalized * float64(uint64(1)<<coordBits-1))
+}
+
+// quantizeRange quantizes a [min, max] sub-range of [lo, hi].
+func quantizeRange(min, max, lo, hi float64) (uint32, uint32) {
+	return quantize(min, lo, hi), quantize(max, lo, hi)
+}
+
+// interleave bit-interleaves two 32-bit coordinates into a 64-bit Morton
+// code, latitude in the even bit positions and longitude in the odd
+// positions.
+func interleave(lat, lon uint32) uint64 {
+	return spread(lat) | (spread(lon) << 1)
+}
+
+func spread(x uint32) uint64 {
+	v := uint64(x)
+	v = (v | (v << 16)) & 0x0000FFFF0000FFFF
+	v = (v | (v << 8)) & 0x00FF00FF00FF00FF
+	v = (v | (v << 4)) & 0x0F0F0F0F0F0F0F0F
+	v = (v | (v << 2)) & 0x3333333333333333
+	v = (v | (v << 1)) & 0x5555555555555555
+	return v
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,112 @@
+package spatial
+
+import (
+	"testing"
+
+	"point"
+)
+
+type testPoint struct {
+	lat, lon float64
+	name     string
+}
+
+func (p testPoint) GetLatitude() float64  { return p.lat }
+func (p testPoint) GetLongitude() float64 { return p.lon }
+
+func buildIndex(points []testPoint) *Index {
+	idx := NewIndex()
+	for _, p := range points {
+		idx.Insert(p)
+	}
+	return idx
+}
+
+func containsName(results []point.LatLonPoint, name string) bool {
+	for _, r := range results {
+		if tp, ok := r.(testPoint); ok && tp.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWithinRadiusFindsNearbyPoints(t *testing.T) {
+	sf := testPoint{37.7749, -122.4194, "sf"}
+	oakland := testPoint{37.8044, -122.2712, "oakland"}
+	nyc := testPoint{40.7128, -74.0060, "nyc"}
+
+	idx := buildIndex([]testPoint{sf, oakland, nyc})
+
+	results := idx.WithinRadius(sf, 50000)
+	if !containsName(results, "sf") {
+		t.Errorf("WithinRadius did not include the query point itself")
+	}
+	if !containsName(results, "oakland") {
+		t.Errorf("WithinRadius(sf, 50km) missing oakland (~13km away)")
+	}
+	if containsName(results, "nyc") {
+		t.Errorf("WithinRadius(sf, 50km) unexpectedly included nyc")
+	}
+}
+
+func TestWithinRadiusAcrossAntimeridian(t *testing.T) {
+	west := testPoint{0, 179.99, "west-of-dateline"}
+	east := testPoint{0, -179.99, "east-of-dateline"}
+
+	idx := buildIndex([]testPoint{west, east})
+
+	// The two points are ~2.2km apart across the antimeridian; a 10km
+	// radius query from either side must find the other.
+	results := idx.WithinRadius(west, 10000)
+	if !containsName(results, "east-of-dateline") {
+		t.Errorf("WithinRadius across the antimeridian missed the point on the other side; got %v", results)
+	}
+}
+
+func TestNearestK(t *testing.T) {
+	sf := testPoint{37.7749, -122.4194, "sf"}
+	oakland := testPoint{37.8044, -122.2712, "oakland"}
+	la := testPoint{34.0522, -118.2437, "la"}
+	nyc := testPoint{40.7128, -74.0060, "nyc"}
+
+	idx := buildIndex([]testPoint{sf, oakland, la, nyc})
+
+	nearest := idx.NearestK(sf, 2)
+	if len(nearest) != 2 {
+		t.Fatalf("NearestK(sf, 2) returned %d points, want 2", len(nearest))
+	}
+	if !containsName(nearest, "sf") || !containsName(nearest, "oakland") {
+		t.Errorf("NearestK(sf, 2) = %v, want [sf, oakland]", nearest)
+	}
+}
+
+func TestNearestKMoreThanAvailable(t *testing.T) {
+	sf := testPoint{37.7749, -122.4194, "sf"}
+	idx := buildIndex([]testPoint{sf})
+
+	nearest := idx.NearestK(sf, 5)
+	if len(nearest) != 1 {
+		t.Fatalf("NearestK with k > len(index) returned %d points, want 1", len(nearest))
+	}
+}
+
+// TestCoveringRangesStaysBounded guards against the quadtree decomposition
+// regressing into an unbounded scan: it should stop subdividing once a
+// cell has shrunk to the query box's own scale, not recurse to
+// maxQuadDepth along the whole box perimeter.
+func TestCoveringRangesStaysBounded(t *testing.T) {
+	sf := testPoint{37.7749, -122.4194, "sf"}
+	idx := buildIndex([]testPoint{sf})
+
+	for _, meters := range []float64{1000, 256000, 8200000} {
+		latMin, latMax, lonMin, lonMax := boundingBox(sf, meters)
+		qLatMin, qLatMax := quantizeRange(latMin, latMax, -90, 90)
+		qLonMin, qLonMax := quantizeRange(lonMin, lonMax, -180, 180)
+
+		ranges := idx.coveringRanges(qLatMin, qLatMax, qLonMin, qLonMax)
+		if len(ranges) > 64 {
+			t.Errorf("coveringRanges(radius=%.0fm) produced %d ranges, want a small bounded count", meters, len(ranges))
+		}
+	}
+}